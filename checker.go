@@ -2,6 +2,7 @@ package lintpack
 
 import (
 	"go/ast"
+	"sync"
 )
 
 type checkerProto struct {
@@ -17,10 +18,18 @@ type Checker struct {
 	fileWalker FileWalker
 
 	Init func(ctx *Context)
+
+	// mu serializes Check, since a Checker may be reused for several
+	// files: ctx.warnings is reused across calls, so two files can't
+	// be checked at once by the same Checker.
+	mu sync.Mutex
 }
 
 // Check runs rule checker over file f.
 func (c *Checker) Check(f *ast.File) []Warning {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.ctx.warnings = c.ctx.warnings[:0]
 	c.fileWalker.WalkFile(f)
 	return c.ctx.warnings
@@ -0,0 +1,40 @@
+package lintpack
+
+import "path/filepath"
+
+// ExcludeSet is a registry of fully-qualified symbol patterns (e.g.
+// "fmt.Print*", "(*bytes.Buffer).Write*") whose diagnostics are
+// dropped for every checker that opts in via
+// CheckerInfo.AppliesToExclusions.
+//
+// Patterns are matched against a call's fully qualified name using
+// path/filepath.Match syntax.
+type ExcludeSet struct {
+	patterns []string
+}
+
+// Excludes is the exclude set consulted by the check command. Checker
+// authors and users both add to it through AddExcludes.
+var Excludes = &ExcludeSet{}
+
+// AddExcludes registers additional exclude patterns on top of
+// whatever Excludes already holds.
+func AddExcludes(patterns ...string) {
+	Excludes.patterns = append(Excludes.patterns, patterns...)
+}
+
+// Match reports whether name matches any registered pattern.
+func (s *ExcludeSet) Match(name string) bool {
+	for _, pattern := range s.patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Patterns returns a copy of the patterns registered so far, e.g. so a
+// cache key can account for them.
+func (s *ExcludeSet) Patterns() []string {
+	return append([]string(nil), s.patterns...)
+}
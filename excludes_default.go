@@ -0,0 +1,17 @@
+package lintpack
+
+// DefaultExcludes is a curated list of fully-qualified standard
+// library symbols whose return values or side effects are commonly
+// ignored on purpose. It mirrors errcheck's own default exclude list.
+//
+// It is not loaded automatically; pass -excludeDefault to the check
+// command, or call AddExcludes(DefaultExcludes...) directly.
+var DefaultExcludes = []string{
+	"fmt.Print*",
+	"fmt.Fprint*",
+	"(*bytes.Buffer).Write*",
+	"(*strings.Builder).Write*",
+	"(*os.File).Close",
+	"(*text/template.Template).Execute*",
+	"(*html/template.Template).Execute*",
+}
@@ -0,0 +1,115 @@
+// Package cache implements an on-disk cache of per-package lint
+// results, keyed by a fingerprint of everything that can influence
+// them, so unchanged packages can be skipped on repeat runs.
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Mode selects how the cache is used for a run.
+type Mode int
+
+const (
+	// Off disables the cache: every package is checked and nothing
+	// is read from or written to disk.
+	Off Mode = iota
+	// ReadOnly replays cached results but never writes new ones.
+	ReadOnly
+	// ReadWrite both replays and persists results.
+	ReadWrite
+)
+
+// ParseMode parses the -cache flag value.
+func ParseMode(s string) (Mode, error) {
+	switch s {
+	case "off":
+		return Off, nil
+	case "readonly":
+		return ReadOnly, nil
+	case "readwrite":
+		return ReadWrite, nil
+	default:
+		return Off, fmt.Errorf("unknown cache mode %q, want one of: off, readonly, readwrite", s)
+	}
+}
+
+// DefaultDir returns $XDG_CACHE_HOME/lintpack, falling back to
+// os.UserCacheDir()/lintpack.
+func DefaultDir() string {
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "lintpack")
+	}
+	if dir, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(dir, "lintpack")
+	}
+	return filepath.Join(os.TempDir(), "lintpack-cache")
+}
+
+// Entry is a single cached warning, detached from go/ast and go/types
+// so it can be serialized and replayed without re-checking the package.
+type Entry struct {
+	Checker  string `json:"checker"`
+	Filename string `json:"filename"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+}
+
+// Cache memoizes the set of Entry values produced for a package
+// fingerprint.
+type Cache struct {
+	dir  string
+	mode Mode
+}
+
+// Open returns a Cache rooted at dir, operating in the given mode.
+func Open(dir string, mode Mode) *Cache {
+	return &Cache{dir: dir, mode: mode}
+}
+
+func (c *Cache) path(key string) string {
+	// Shard by the key's first two characters to avoid a single huge
+	// directory, the same trick go build's own cache uses.
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
+
+// Lookup returns the cached entries for key, if reads are enabled and
+// an entry for key exists.
+func (c *Cache) Lookup(key string) ([]Entry, bool) {
+	if c.mode == Off {
+		return nil, false
+	}
+
+	data, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, false
+	}
+	return entries, true
+}
+
+// Store persists entries under key, if writes are enabled.
+func (c *Cache) Store(key string, entries []Entry) error {
+	if c.mode != ReadWrite {
+		return nil
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0o644)
+}
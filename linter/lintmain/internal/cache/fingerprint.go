@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"os"
+)
+
+// Fingerprint incrementally hashes whatever is fed into it (strings,
+// file contents) into a single cache key.
+type Fingerprint struct {
+	h hash.Hash
+}
+
+// NewFingerprint returns an empty Fingerprint.
+func NewFingerprint() *Fingerprint {
+	return &Fingerprint{h: sha256.New()}
+}
+
+// WriteString mixes s into the fingerprint.
+func (fp *Fingerprint) WriteString(s string) {
+	io.WriteString(fp.h, s)
+	fp.h.Write([]byte{0})
+}
+
+// WriteFile mixes the contents of the file at path into the
+// fingerprint.
+func (fp *Fingerprint) WriteFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(fp.h, f); err != nil {
+		return err
+	}
+	fp.h.Write([]byte{0})
+	return nil
+}
+
+// Sum returns the hex-encoded digest of everything written so far.
+func (fp *Fingerprint) Sum() string {
+	return hex.EncodeToString(fp.h.Sum(nil))
+}
@@ -5,21 +5,29 @@ import (
 	"flag"
 	"fmt"
 	"go/ast"
-	"go/build"
 	"go/parser"
+	"go/token"
 	"go/types"
+	"io/ioutil"
 	"log"
+	"math"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-lintpack/lintpack"
+	"github.com/go-lintpack/lintpack/linter/lintmain/internal/cache"
 	"github.com/go-lintpack/lintpack/linter/lintmain/internal/hotload"
-	"github.com/logrusorgru/aurora"
-	"golang.org/x/tools/go/loader"
+	"github.com/go-lintpack/lintpack/linter/lintmain/internal/pkgload"
+	"github.com/go-lintpack/lintpack/linter/lintmain/internal/reporter"
+	"golang.org/x/tools/go/packages"
+	yaml "gopkg.in/yaml.v2"
 )
 
 // Main implements sub-command entry point.
@@ -30,11 +38,22 @@ func Main() {
 		name string
 		fn   func() error
 	}{
+		{"bind checker params", l.bindCheckerParams},
 		{"parse args", l.parseArgs},
+		{"assign checker params", l.assignCheckerParams},
+		{"start profiling", l.startProfiling},
+		{"init reporter", l.initReporter},
+		{"init cache", l.initCache},
 		{"load program", l.loadProgram},
 		{"load plugin", l.loadPlugin},
 		{"init checkers", l.initCheckers},
+		{"load excludes", l.loadExcludes},
 		{"run checkers", l.runCheckers},
+		{"emit results", l.emitResults},
+		{"report unmatched ignore directives", l.reportUnmatchedIgnores},
+		{"print checker timings", l.printCheckerTimings},
+		{"flush reporter", l.flushReporter},
+		{"stop profiling", l.stopProfiling},
 		{"exit if found issues", l.exit},
 	}
 
@@ -48,13 +67,41 @@ func Main() {
 type linter struct {
 	ctx *lintpack.Context
 
-	prog *loader.Program
+	pkgs  []*packages.Package
+	sizes types.Sizes
 
 	checkers []*lintpack.Checker
 
 	packages []string
 
-	foundIssues bool
+	cache     *cache.Cache
+	cacheMode string
+	cacheDir  string
+	buildID   string
+
+	reporter   reporter.Reporter
+	format     string
+	foundIssue bool
+
+	parallelism int
+
+	allMu      sync.Mutex
+	allEntries []cache.Entry
+
+	measureCheckers bool
+	timingMu        sync.Mutex
+	timings         []checkerTiming
+
+	cpuProfile      string
+	memProfile      string
+	traceFile       string
+	cpuProfileFile  *os.File
+	traceFileHandle *os.File
+
+	ignoreMu    sync.Mutex
+	lineIgnores []*LineIgnore
+	fileIgnores []*FileIgnore
+	noIgnore    bool
 
 	filters struct {
 		disableTags *regexp.Regexp
@@ -63,7 +110,10 @@ type linter struct {
 		enable      *regexp.Regexp
 	}
 
-	pluginPath string
+	pluginPath         string
+	configPath         string
+	excludeSymbolsPath string
+	excludeDefault     bool
 
 	exitCode           int
 	checkTests         bool
@@ -74,37 +124,219 @@ type linter struct {
 }
 
 func (l *linter) exit() error {
-	if l.foundIssues {
+	if l.foundIssue {
 		os.Exit(l.exitCode)
 	}
 	return nil
 }
 
+// initReporter constructs the Reporter selected via -format.
+func (l *linter) initReporter() error {
+	rep, err := reporter.New(l.format, os.Stdout, l.coloredOutput, l.shorterErrLocation)
+	if err != nil {
+		return err
+	}
+	l.reporter = rep
+	return nil
+}
+
+func (l *linter) flushReporter() error {
+	return l.reporter.Flush()
+}
+
+// initCache opens the on-disk result cache and computes the running
+// binary's build id, used as part of every cache key.
+func (l *linter) initCache() error {
+	mode, err := cache.ParseMode(l.cacheMode)
+	if err != nil {
+		return fmt.Errorf("-cache: %v", err)
+	}
+
+	dir := l.cacheDir
+	if dir == "" {
+		dir = cache.DefaultDir()
+	}
+	l.cache = cache.Open(dir, mode)
+
+	id, err := computeBuildID()
+	if err != nil {
+		return fmt.Errorf("compute build id: %v", err)
+	}
+	l.buildID = id
+
+	return nil
+}
+
+// computeBuildID fingerprints the running lintpack binary itself, so
+// the cache is invalidated whenever the linter (and therefore any
+// checker) changes.
+func computeBuildID() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	fp := cache.NewFingerprint()
+	if err := fp.WriteFile(exe); err != nil {
+		return "", err
+	}
+	return fp.Sum(), nil
+}
+
+// cacheKey fingerprints everything that can affect pkg's check
+// results: the binary, the enabled checkers and their resolved
+// parameters, the Go toolchain and type sizes, the content of pkg's
+// own files plus its dependencies' export data, and every flag that
+// filters or drops warnings after a checker produces them. Entries
+// are cached already filtered, so a flag like -excludeDefault or
+// -checkGenerated has to be part of the key, or flipping it would
+// just replay a stale, wrongly-filtered result from before.
+func (l *linter) cacheKey(pkg *packages.Package) string {
+	fp := cache.NewFingerprint()
+	fp.WriteString(l.buildID)
+	fp.WriteString(runtime.Version())
+	fp.WriteString(fmt.Sprint(l.sizes))
+	fp.WriteString(fmt.Sprintf("checkTests=%v checkGenerated=%v noIgnore=%v",
+		l.checkTests, l.checkGenerated, l.noIgnore))
+
+	excludes := lintpack.Excludes.Patterns()
+	sort.Strings(excludes)
+	for _, pattern := range excludes {
+		fp.WriteString("exclude=" + pattern)
+	}
+
+	checkers := make([]*lintpack.Checker, len(l.checkers))
+	copy(checkers, l.checkers)
+	sort.Slice(checkers, func(i, j int) bool {
+		return checkers[i].Info.Name < checkers[j].Info.Name
+	})
+	for _, c := range checkers {
+		fp.WriteString(c.Info.Name)
+
+		pnames := make([]string, 0, len(c.Info.Params))
+		for pname := range c.Info.Params {
+			pnames = append(pnames, pname)
+		}
+		sort.Strings(pnames)
+		for _, pname := range pnames {
+			fp.WriteString(fmt.Sprintf("%s=%v", pname, c.Info.Params[pname].Value))
+		}
+	}
+
+	for _, filename := range pkg.GoFiles {
+		if err := fp.WriteFile(filename); err != nil {
+			log.Printf("cache: hash %s: %v", filename, err)
+		}
+	}
+
+	impPaths := make([]string, 0, len(pkg.Imports))
+	for path := range pkg.Imports {
+		impPaths = append(impPaths, path)
+	}
+	sort.Strings(impPaths)
+	for _, path := range impPaths {
+		export := pkg.Imports[path].ExportFile
+		if export == "" {
+			continue
+		}
+		if err := fp.WriteFile(export); err != nil {
+			log.Printf("cache: hash %s: %v", export, err)
+		}
+	}
+
+	return fp.Sum()
+}
+
+// replayCached re-queues previously cached entries for reporting, as
+// if checkFile had just produced them.
+func (l *linter) replayCached(entries []cache.Entry) {
+	l.allMu.Lock()
+	l.allEntries = append(l.allEntries, entries...)
+	l.allMu.Unlock()
+}
+
+// runCheckers checks every package unit, running up to -j units
+// concurrently through a bounded worker pool. Results are collected
+// into l.allEntries rather than reported immediately, so emitResults
+// can print them in a deterministic order afterwards.
 func (l *linter) runCheckers() error {
-	pkgInfoMap := make(map[string]*loader.PackageInfo)
-	for _, pkgInfo := range l.prog.AllPackages {
-		pkgInfoMap[pkgInfo.Pkg.Path()] = pkgInfo
-	}
-	for _, pkgPath := range l.packages {
-		pkgInfo := pkgInfoMap[pkgPath]
-		if pkgInfo == nil || !pkgInfo.TransitivelyErrorFree {
-			log.Fatalf("%s package is not properly loaded", pkgPath)
+	var units []*pkgload.Unit
+	pkgload.VisitUnits(l.pkgs, func(u *pkgload.Unit) {
+		units = append(units, u)
+	})
+
+	sem := make(chan struct{}, l.parallelism)
+	var wg sync.WaitGroup
+	for _, u := range units {
+		if u.Base == nil {
+			log.Fatalf("package is not properly loaded")
+		}
+		u := u
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			// Check the package itself.
+			l.checkPackage(u.Base)
+			// Check package external test (if any).
+			if u.Test != nil {
+				l.checkPackage(u.Test)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// emitResults sorts every collected entry by file, line and checker
+// name so output is reproducible regardless of how packages and
+// checkers were scheduled, then reports them all.
+func (l *linter) emitResults() error {
+	sort.Slice(l.allEntries, func(i, j int) bool {
+		a, b := l.allEntries[i], l.allEntries[j]
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
 		}
-		// Check the package itself.
-		l.checkPackage(pkgPath, pkgInfo)
-		// Check package external test (if any).
-		pkgInfo = pkgInfoMap[pkgPath+"_test"]
-		if pkgInfo != nil {
-			l.checkPackage(pkgPath+"_test", pkgInfo)
+		if a.Checker != b.Checker {
+			return a.Checker < b.Checker
 		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		return a.Message < b.Message
+	})
+
+	for _, e := range l.allEntries {
+		l.reporter.Report(reporter.Issue(e))
 	}
+	l.foundIssue = len(l.allEntries) > 0
 
 	return nil
 }
 
-func (l *linter) checkPackage(pkgPath string, pkgInfo *loader.PackageInfo) {
-	l.ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
-	for _, f := range pkgInfo.Files {
+// checkPackage checks pkg. Every call gets its own *lintpack.Context
+// (and its own Checker instances, built against that Context) rather
+// than sharing l.ctx, since Context.Set{Package,File}Info mutate
+// state a Checker reads from mid-walk: sharing one across concurrent
+// packages would let one package's types clobber another's while
+// checkFile is still walking it. l.ctx itself stays untouched after
+// loadProgram and is only used as a read-only template (its FileSet
+// and the CheckerInfo of l.checkers).
+func (l *linter) checkPackage(pkg *packages.Package) {
+	if len(pkg.Errors) != 0 {
+		log.Fatalf("%s package is not properly loaded", pkg.PkgPath)
+	}
+
+	type file struct {
+		name string
+		f    *ast.File
+	}
+	var files []file
+	for _, f := range pkg.Syntax {
 		filename := l.getFilename(f)
 		if !l.checkTests && strings.HasSuffix(filename, "_test.go") {
 			continue
@@ -112,17 +344,59 @@ func (l *linter) checkPackage(pkgPath string, pkgInfo *loader.PackageInfo) {
 		if !l.checkGenerated && l.isGenerated(f) {
 			continue
 		}
-		l.ctx.SetFileInfo(filename, f)
-		l.checkFile(f)
+		if !l.noIgnore {
+			// Collected unconditionally, before the cache lookup
+			// below can return early, so reportUnmatchedIgnores sees
+			// every package's directives regardless of cache state.
+			l.collectIgnoreDirectives(filename, f)
+		}
+		files = append(files, file{filename, f})
 	}
+
+	key := l.cacheKey(pkg)
+	if entries, ok := l.cache.Lookup(key); ok {
+		l.replayCached(entries)
+		return
+	}
+
+	ctx := lintpack.NewContext(l.ctx.FileSet, l.sizes)
+	ctx.SetPackageInfo(pkg.TypesInfo, pkg.Types)
+	checkers := l.newCheckers(ctx)
+
+	var collected []cache.Entry
+	for _, fl := range files {
+		ctx.SetFileInfo(fl.name, fl.f)
+		collected = append(collected, l.checkFile(ctx, checkers, fl.name, fl.f)...)
+	}
+
+	if err := l.cache.Store(key, collected); err != nil {
+		log.Printf("cache: store %s: %v", pkg.PkgPath, err)
+	}
+
+	l.allMu.Lock()
+	l.allEntries = append(l.allEntries, collected...)
+	l.allMu.Unlock()
 }
 
-func (l *linter) checkFile(f *ast.File) {
+// newCheckers builds a fresh set of Checker instances bound to ctx,
+// one per entry in l.checkers, so a package being checked concurrently
+// with others never shares a Checker (and its internal warnings
+// buffer) with them.
+func (l *linter) newCheckers(ctx *lintpack.Context) []*lintpack.Checker {
+	checkers := make([]*lintpack.Checker, len(l.checkers))
+	for i, c := range l.checkers {
+		checkers[i] = lintpack.NewChecker(ctx, c.Info)
+	}
+	return checkers
+}
+
+func (l *linter) checkFile(ctx *lintpack.Context, checkers []*lintpack.Checker, filename string, f *ast.File) []cache.Entry {
 	var wg sync.WaitGroup
-	wg.Add(len(l.checkers))
-	for _, c := range l.checkers {
-		// All checkers are expected to use *lint.Context
-		// as read-only structure, so no copying is required.
+	var entriesMu sync.Mutex
+	var entries []cache.Entry
+
+	wg.Add(len(checkers))
+	for _, c := range checkers {
 		go func(c *lintpack.Checker) {
 			defer func() {
 				wg.Done()
@@ -141,18 +415,64 @@ func (l *linter) checkFile(f *ast.File) {
 				}
 			}()
 
-			for _, warn := range c.Check(f) {
-				l.foundIssues = true
-				loc := l.ctx.FileSet.Position(warn.Node.Pos()).String()
-				if l.shorterErrLocation {
-					loc = shortenLocation(loc)
+			start := time.Now()
+			warns := c.Check(f)
+			if l.measureCheckers {
+				l.recordTiming(c.Info.Name, filename, time.Since(start))
+			}
+
+			for _, warn := range warns {
+				pos := ctx.FileSet.Position(warn.Node.Pos())
+				if !l.noIgnore && l.isIgnored(filename, pos.Line, c.Info.Name) {
+					continue
+				}
+				if c.Info.AppliesToExclusions {
+					if name, ok := excludedCallName(f, ctx.TypesInfo, warn.Node); ok && lintpack.Excludes.Match(name) {
+						continue
+					}
+				}
+
+				entry := cache.Entry{
+					Checker:  c.Info.Name,
+					Filename: pos.Filename,
+					Line:     pos.Line,
+					Column:   pos.Column,
+					Message:  warn.Text,
+					Severity: warn.Severity,
 				}
 
-				printWarning(l, c.Info.Name, loc, warn.Text)
+				entriesMu.Lock()
+				entries = append(entries, entry)
+				entriesMu.Unlock()
 			}
 		}(c)
 	}
 	wg.Wait()
+	return entries
+}
+
+// loadExcludes populates lintpack.Excludes from -excludeDefault and
+// -excludeSymbols before any checker runs.
+func (l *linter) loadExcludes() error {
+	if l.excludeDefault {
+		lintpack.AddExcludes(lintpack.DefaultExcludes...)
+	}
+	if l.excludeSymbolsPath == "" {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(l.excludeSymbolsPath)
+	if err != nil {
+		return fmt.Errorf("read -excludeSymbols: %v", err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lintpack.AddExcludes(line)
+	}
+	return nil
 }
 
 func (l *linter) initCheckers() error {
@@ -210,33 +530,179 @@ func (l *linter) initCheckers() error {
 	return nil
 }
 
+// bindCheckerParams registers every checker's parameters as CLI flags,
+// named "@<checker>.<param>". It must run before flag.Parse, so it is
+// placed ahead of parseArgs in the Main pipeline.
+func (l *linter) bindCheckerParams() error {
+	for _, info := range lintpack.GetCheckersInfo() {
+		for pname, param := range info.Params {
+			name := fmt.Sprintf("@%s.%s", info.Name, pname)
+			flag.Var(paramFlag{param: param}, name, param.Usage)
+		}
+	}
+	return nil
+}
+
+// assignCheckerParams overlays config file defaults onto checker
+// parameters that were not explicitly set on the command line.
+// CLI flags always win over the config file.
+func (l *linter) assignCheckerParams() error {
+	if l.configPath == "" {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(l.configPath)
+	if err != nil {
+		return fmt.Errorf("read config: %v", err)
+	}
+	var cfg map[string]map[string]interface{}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("parse config %s: %v", l.configPath, err)
+	}
+
+	explicitFlags := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { explicitFlags[f.Name] = true })
+
+	for _, info := range lintpack.GetCheckersInfo() {
+		values := cfg[info.Name]
+		for pname, param := range info.Params {
+			flagName := fmt.Sprintf("@%s.%s", info.Name, pname)
+			if explicitFlags[flagName] {
+				continue
+			}
+			v, ok := values[pname]
+			if !ok {
+				continue
+			}
+			if err := convertParamValue(param, v); err != nil {
+				return fmt.Errorf("%s: %s.%s: %v", l.configPath, info.Name, pname, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// convertParamValue assigns v, a raw value decoded from YAML, to
+// param.Value, converting it to param's existing concrete type (the
+// same int/bool/string rule paramFlag.Set applies to CLI flags) so
+// later unchecked type assertions like CheckerParams.Int can't panic
+// on a config file typo such as an int param written as "10.0" or a
+// quoted number.
+func convertParamValue(param *lintpack.CheckerParam, v interface{}) error {
+	switch cur := param.Value.(type) {
+	case int:
+		switch x := v.(type) {
+		case int:
+			param.Value = x
+		case float64:
+			if x != math.Trunc(x) {
+				return fmt.Errorf("want int, got non-integer number %v", x)
+			}
+			param.Value = int(x)
+		case string:
+			n, err := strconv.Atoi(x)
+			if err != nil {
+				return fmt.Errorf("want int, got string %q", x)
+			}
+			param.Value = n
+		default:
+			return fmt.Errorf("want int, got %T", v)
+		}
+	case bool:
+		switch x := v.(type) {
+		case bool:
+			param.Value = x
+		case string:
+			b, err := strconv.ParseBool(x)
+			if err != nil {
+				return fmt.Errorf("want bool, got string %q", x)
+			}
+			param.Value = b
+		default:
+			return fmt.Errorf("want bool, got %T", v)
+		}
+	case string:
+		x, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("want string, got %T", v)
+		}
+		param.Value = x
+	default:
+		return fmt.Errorf("unsupported parameter type %T", cur)
+	}
+	return nil
+}
+
+// paramFlag adapts a *lintpack.CheckerParam to the flag.Value interface
+// so its value can be registered as a flag without lintpack knowing
+// anything about the flag package.
+type paramFlag struct {
+	param *lintpack.CheckerParam
+}
+
+func (f paramFlag) String() string {
+	if f.param == nil {
+		return ""
+	}
+	return fmt.Sprint(f.param.Value)
+}
+
+func (f paramFlag) Set(s string) error {
+	switch f.param.Value.(type) {
+	case int:
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		f.param.Value = v
+	case bool:
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		f.param.Value = v
+	default:
+		f.param.Value = s
+	}
+	return nil
+}
+
 func (l *linter) loadProgram() error {
 	sizes := types.SizesFor("gc", runtime.GOARCH)
 	if sizes == nil {
 		return fmt.Errorf("can't find sizes info for %s", runtime.GOARCH)
 	}
 
-	conf := loader.Config{
-		ParserMode: parser.ParseComments,
-		TypeChecker: types.Config{
-			Sizes: sizes,
-		},
+	fset := token.NewFileSet()
+	conf := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo | packages.NeedDeps |
+			packages.NeedImports | packages.NeedTypesSizes | packages.NeedExportFile,
+		Fset:      fset,
+		Tests:     l.checkTests,
+		ParseFile: parseFile,
 	}
 
-	if _, err := conf.FromArgs(l.packages, true); err != nil {
-		log.Fatalf("resolve packages: %v", err)
-	}
-	prog, err := conf.Load()
+	pkgs, err := packages.Load(conf, l.packages...)
 	if err != nil {
-		log.Fatalf("load program: %v", err)
+		log.Fatalf("load packages: %v", err)
+	}
+	if packages.PrintErrors(pkgs) != 0 {
+		log.Fatalf("packages contain errors")
 	}
 
-	l.prog = prog
-	l.ctx = lintpack.NewContext(prog.Fset, sizes)
+	l.pkgs = pkgs
+	l.sizes = sizes
+	l.ctx = lintpack.NewContext(fset, sizes)
 
 	return nil
 }
 
+func parseFile(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+	return parser.ParseFile(fset, filename, src, parser.ParseComments)
+}
+
 func (l *linter) loadPlugin() error {
 	return hotload.CheckersFromDylib(l.pluginPath)
 }
@@ -244,6 +710,8 @@ func (l *linter) loadPlugin() error {
 func (l *linter) parseArgs() error {
 	flag.StringVar(&l.pluginPath, "pluginPath", "",
 		`path to a Go plugin that provides additional checks`)
+	flag.StringVar(&l.configPath, "config", "",
+		`path to a YAML config file with per-checker parameter defaults`)
 	disableTags := flag.String("disableTags", `^experimental$|^performance$|^opinionated$`,
 		`regexp that excludes checkers that have matching tag`)
 	disable := flag.String("disable", `<none>`,
@@ -262,6 +730,28 @@ func (l *linter) parseArgs() error {
 		`whether to use colored output`)
 	flag.BoolVar(&l.verbose, `verbose`, false,
 		`whether to print output useful during linter debugging`)
+	flag.BoolVar(&l.noIgnore, `noIgnore`, false,
+		`whether to disable //lintpack:ignore and //lintpack:file-ignore directives`)
+	flag.StringVar(&l.format, `format`, `text`,
+		`result output format: text, json, checkstyle or sarif`)
+	flag.StringVar(&l.cacheMode, `cache`, `readwrite`,
+		`result cache mode: off, readonly or readwrite`)
+	flag.StringVar(&l.cacheDir, `cacheDir`, ``,
+		`result cache directory; defaults to $XDG_CACHE_HOME/lintpack`)
+	flag.StringVar(&l.excludeSymbolsPath, `excludeSymbols`, ``,
+		`path to a file with newline-separated symbol patterns to exclude from reporting`)
+	flag.BoolVar(&l.excludeDefault, `excludeDefault`, false,
+		`whether to load lintpack.DefaultExcludes`)
+	flag.IntVar(&l.parallelism, `j`, runtime.GOMAXPROCS(0),
+		`max number of packages checked in parallel`)
+	flag.StringVar(&l.cpuProfile, `cpuprofile`, ``,
+		`write a CPU profile to this file`)
+	flag.StringVar(&l.memProfile, `memprofile`, ``,
+		`write a memory profile to this file`)
+	flag.StringVar(&l.traceFile, `trace`, ``,
+		`write an execution trace to this file`)
+	flag.BoolVar(&l.measureCheckers, `debug.measureCheckers`, false,
+		`whether to print per-checker timing on exit`)
 
 	flag.Parse()
 
@@ -280,6 +770,10 @@ func (l *linter) parseArgs() error {
 	if err != nil {
 		return fmt.Errorf("-enableTags: %v", err)
 	}
+	if l.parallelism < 1 {
+		l.parallelism = 1
+	}
+
 	l.filters.enable, err = regexp.Compile(*enable)
 	if err != nil {
 		return fmt.Errorf("-enable: %v", err)
@@ -297,29 +791,6 @@ func (l *linter) isGenerated(f *ast.File) bool {
 
 func (l *linter) getFilename(f *ast.File) string {
 	// See https://github.com/golang/go/issues/24498.
-	return filepath.Base(l.prog.Fset.Position(f.Pos()).Filename)
-}
-
-func shortenLocation(loc string) string {
-	switch {
-	case strings.HasPrefix(loc, build.Default.GOPATH):
-		return strings.Replace(loc, build.Default.GOPATH, "$GOPATH", 1)
-	case strings.HasPrefix(loc, build.Default.GOROOT):
-		return strings.Replace(loc, build.Default.GOROOT, "$GOROOT", 1)
-	default:
-		return loc
-	}
+	return filepath.Base(l.ctx.FileSet.Position(f.Pos()).Filename)
 }
 
-func printWarning(l *linter, rule, loc, warn string) {
-	switch {
-	case l.coloredOutput:
-		log.Printf("%v: %v: %v\n",
-			aurora.Magenta(aurora.Bold(loc)),
-			aurora.Red(rule),
-			warn)
-
-	default:
-		log.Printf("%s: %s: %s\n", loc, rule, warn)
-	}
-}
@@ -0,0 +1,56 @@
+package check
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+)
+
+// excludedCallName finds the call expression enclosing node and
+// resolves its fully qualified symbol name (e.g. "fmt.Println" or
+// "(*bytes.Buffer).Write"), so it can be matched against
+// lintpack.Excludes. It returns ok=false if node isn't part of a call.
+func excludedCallName(f *ast.File, info *types.Info, node ast.Node) (name string, ok bool) {
+	if info == nil {
+		return "", false
+	}
+
+	var call *ast.CallExpr
+	ast.Inspect(f, func(n ast.Node) bool {
+		if n == nil || n.Pos() > node.Pos() || n.End() < node.Pos() {
+			return false
+		}
+		if c, isCall := n.(*ast.CallExpr); isCall {
+			call = c
+		}
+		return true
+	})
+	if call == nil {
+		return "", false
+	}
+
+	name = qualifiedFuncName(info, call.Fun)
+	return name, name != ""
+}
+
+// qualifiedFuncName resolves fun (a call's callee expression) to a
+// "pkgpath.Name" or "(*pkgpath.Type).Method" string using type info.
+func qualifiedFuncName(info *types.Info, fun ast.Expr) string {
+	switch fn := fun.(type) {
+	case *ast.Ident:
+		obj := info.Uses[fn]
+		if obj == nil || obj.Pkg() == nil {
+			return ""
+		}
+		return obj.Pkg().Path() + "." + obj.Name()
+
+	case *ast.SelectorExpr:
+		if sel, ok := info.Selections[fn]; ok {
+			return fmt.Sprintf("(%s).%s", sel.Recv(), fn.Sel.Name)
+		}
+		if obj := info.Uses[fn.Sel]; obj != nil && obj.Pkg() != nil {
+			return obj.Pkg().Path() + "." + obj.Name()
+		}
+	}
+	return ""
+}
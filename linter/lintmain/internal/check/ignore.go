@@ -0,0 +1,143 @@
+package check
+
+import (
+	"go/ast"
+	"log"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	ignoreDirective     = "lintpack:ignore "
+	fileIgnoreDirective = "lintpack:file-ignore "
+)
+
+// LineIgnore is a //lintpack:ignore directive attached to a single
+// source line. Any warning produced by one of Checks at (File, Line)
+// is dropped.
+//
+// matched records whether the directive ever suppressed a warning, so
+// a stale directive can be reported once the run is over.
+type LineIgnore struct {
+	File   string
+	Line   int
+	Checks []string
+
+	matched bool
+}
+
+// FileIgnore is a //lintpack:file-ignore directive: every warning
+// produced by one of Checks anywhere in File is dropped.
+type FileIgnore struct {
+	File   string
+	Checks []string
+}
+
+func (ign *LineIgnore) appliesTo(checker string) bool {
+	return matchesAnyCheck(ign.Checks, checker)
+}
+
+func (ign *FileIgnore) appliesTo(checker string) bool {
+	return matchesAnyCheck(ign.Checks, checker)
+}
+
+func matchesAnyCheck(patterns []string, checker string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, checker); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// collectIgnoreDirectives scans f's comments for lintpack ignore
+// directives that belong to filename and records them on l. Packages
+// are checked concurrently under -j, so this takes l.ignoreMu like
+// isIgnored and reportUnmatchedIgnores do, rather than assuming it
+// runs single-threaded.
+func (l *linter) collectIgnoreDirectives(filename string, f *ast.File) {
+	l.ignoreMu.Lock()
+	defer l.ignoreMu.Unlock()
+
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			switch {
+			case strings.HasPrefix(c.Text, "//"+ignoreDirective):
+				checks, _ := splitDirective(c.Text, ignoreDirective)
+				l.lineIgnores = append(l.lineIgnores, &LineIgnore{
+					File:   filename,
+					Line:   l.ctx.FileSet.Position(c.Pos()).Line,
+					Checks: checks,
+				})
+			case strings.HasPrefix(c.Text, "//"+fileIgnoreDirective):
+				checks, _ := splitDirective(c.Text, fileIgnoreDirective)
+				l.fileIgnores = append(l.fileIgnores, &FileIgnore{
+					File:   filename,
+					Checks: checks,
+				})
+			}
+		}
+	}
+}
+
+// splitDirective splits a directive comment's checker list (everything
+// up to the first space) from its trailing free-form reason.
+//
+// For example, "//lintpack:ignore rangeValCopy,hugeParam slow hot path"
+// yields checks=["rangeValCopy", "hugeParam"], reason="slow hot path".
+func splitDirective(text, directive string) (checks []string, reason string) {
+	body := strings.TrimPrefix(text, "//"+directive)
+	fields := strings.SplitN(strings.TrimSpace(body), " ", 2)
+	checks = strings.Split(fields[0], ",")
+	if len(fields) > 1 {
+		reason = fields[1]
+	}
+	return checks, reason
+}
+
+// isIgnored reports whether a warning from checker at (filename, line)
+// is suppressed by a file-scope or line-scope ignore directive. It
+// marks the matching LineIgnore as used.
+func (l *linter) isIgnored(filename string, line int, checker string) bool {
+	l.ignoreMu.Lock()
+	defer l.ignoreMu.Unlock()
+
+	for _, ign := range l.fileIgnores {
+		if ign.File == filename && ign.appliesTo(checker) {
+			return true
+		}
+	}
+	for _, ign := range l.lineIgnores {
+		if ign.File != filename || !ign.appliesTo(checker) {
+			continue
+		}
+		// A directive may sit on its own line directly above the
+		// node it suppresses, or trail the node on the same line.
+		if line == ign.Line || line == ign.Line+1 {
+			ign.matched = true
+			return true
+		}
+	}
+	return false
+}
+
+// reportUnmatchedIgnores prints a diagnostic for every LineIgnore that
+// never suppressed a warning, mirroring staticcheck's "this linter
+// directive didn't match anything".
+func (l *linter) reportUnmatchedIgnores() error {
+	if l.noIgnore {
+		return nil
+	}
+
+	l.ignoreMu.Lock()
+	defer l.ignoreMu.Unlock()
+
+	for _, ign := range l.lineIgnores {
+		if ign.matched {
+			continue
+		}
+		log.Printf("%s:%d: this linter directive didn't match anything; consider removing it",
+			ign.File, ign.Line)
+	}
+	return nil
+}
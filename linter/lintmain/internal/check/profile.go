@@ -0,0 +1,117 @@
+package check
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"sort"
+	"time"
+)
+
+// checkerTiming is a single "this checker took this long on this
+// file" measurement, collected when -debug.measureCheckers is set.
+type checkerTiming struct {
+	Checker  string
+	Filename string
+	Duration time.Duration
+}
+
+// recordTiming appends a checkerTiming. It is safe for concurrent use
+// from the per-checker goroutines started by checkFile.
+func (l *linter) recordTiming(checker, filename string, d time.Duration) {
+	l.timingMu.Lock()
+	l.timings = append(l.timings, checkerTiming{Checker: checker, Filename: filename, Duration: d})
+	l.timingMu.Unlock()
+}
+
+// printCheckerTimings prints a table of total time spent per checker,
+// slowest first, the same instrumentation staticcheck's lintcmd
+// exposes for finding pathological O(n^2) walks on real code.
+func (l *linter) printCheckerTimings() error {
+	if !l.measureCheckers {
+		return nil
+	}
+
+	total := make(map[string]time.Duration)
+	count := make(map[string]int)
+	for _, t := range l.timings {
+		total[t.Checker] += t.Duration
+		count[t.Checker]++
+	}
+
+	names := make([]string, 0, len(total))
+	for name := range total {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return total[names[i]] > total[names[j]]
+	})
+
+	log.Printf("checker timings (%d measurements):", len(l.timings))
+	for _, name := range names {
+		log.Printf("\t%-32s %10v  (%d files)", name, total[name], count[name])
+	}
+	return nil
+}
+
+// startProfiling turns on whichever of -cpuprofile/-trace were
+// requested. It must run after parseArgs and before any real work
+// starts, so the profile covers package loading too.
+func (l *linter) startProfiling() error {
+	if l.cpuProfile != "" {
+		f, err := os.Create(l.cpuProfile)
+		if err != nil {
+			return fmt.Errorf("create -cpuprofile: %v", err)
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return fmt.Errorf("start cpu profile: %v", err)
+		}
+		l.cpuProfileFile = f
+	}
+
+	if l.traceFile != "" {
+		f, err := os.Create(l.traceFile)
+		if err != nil {
+			return fmt.Errorf("create -trace: %v", err)
+		}
+		if err := trace.Start(f); err != nil {
+			f.Close()
+			return fmt.Errorf("start trace: %v", err)
+		}
+		l.traceFileHandle = f
+	}
+
+	return nil
+}
+
+// stopProfiling finalizes whatever startProfiling turned on and, if
+// -memprofile was given, writes a single heap snapshot. It must run
+// before the process can exit, including the early os.Exit in exit().
+func (l *linter) stopProfiling() error {
+	if l.cpuProfileFile != nil {
+		pprof.StopCPUProfile()
+		l.cpuProfileFile.Close()
+	}
+	if l.traceFileHandle != nil {
+		trace.Stop()
+		l.traceFileHandle.Close()
+	}
+
+	if l.memProfile != "" {
+		f, err := os.Create(l.memProfile)
+		if err != nil {
+			return fmt.Errorf("create -memprofile: %v", err)
+		}
+		defer f.Close()
+		runtime.GC()
+		if err := pprof.WriteHeapProfile(f); err != nil {
+			return fmt.Errorf("write mem profile: %v", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+// Package pkgload provides helpers to work with golang.org/x/tools/go/packages
+// results as lintpack needs them: packages grouped with their external
+// "_test" variants.
+package pkgload
+
+import (
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Unit is a loading unit: a package together with its external test
+// package (the one with "_test" suffix in its package path), if any.
+//
+// packages.Load reports the external test variant as a separate
+// *packages.Package, so checkers that need to see both have to be
+// driven unit-by-unit instead of package-by-package.
+type Unit struct {
+	Base *packages.Package
+	Test *packages.Package
+}
+
+// VisitUnits groups pkgs into Units, preserving the order in which each
+// unit's first package (base or test) appears in pkgs, and calls visit
+// for every unit.
+func VisitUnits(pkgs []*packages.Package, visit func(u *Unit)) {
+	units := make(map[string]*Unit)
+	var order []string
+
+	for _, pkg := range pkgs {
+		if isTestBinary(pkg) {
+			// The synthetic "p.test" command packages.Load builds to
+			// run the tests; there's nothing of its own to check.
+			continue
+		}
+
+		path, isTest := unitKey(pkg)
+
+		u := units[path]
+		if u == nil {
+			u = &Unit{}
+			units[path] = u
+			order = append(order, path)
+		}
+		switch {
+		case isTest:
+			u.Test = pkg
+		case u.Base == nil, isTestAugmented(pkg):
+			// With Tests enabled, the same PkgPath can surface twice:
+			// once plain (ID == PkgPath) and once rebuilt to include
+			// the package's own "_test.go" files (ID "p [p.test]").
+			// The augmented variant is a strict superset, so prefer
+			// it over whichever loaded first.
+			u.Base = pkg
+		}
+	}
+
+	for _, path := range order {
+		visit(units[path])
+	}
+}
+
+// unitKey returns the grouping key for pkg (its PkgPath, with any
+// "_test" external-test-package suffix trimmed) and whether pkg is
+// that external test package.
+func unitKey(pkg *packages.Package) (path string, isTest bool) {
+	isTest = strings.HasSuffix(pkg.PkgPath, "_test")
+	path = strings.TrimSuffix(pkg.PkgPath, "_test")
+	return path, isTest
+}
+
+// isTestAugmented reports whether pkg is a package rebuilt to include
+// its own test files for a -Tests load, as opposed to the plain
+// package. packages.Load gives these a distinct ID, e.g. "p [p.test]",
+// that no longer matches PkgPath.
+func isTestAugmented(pkg *packages.Package) bool {
+	return pkg.ID != pkg.PkgPath
+}
+
+// isTestBinary reports whether pkg is the synthetic "main" command
+// that packages.Load synthesizes to build and run a package's tests,
+// rather than a package worth checking.
+func isTestBinary(pkg *packages.Package) bool {
+	return pkg.Name == "main" && strings.HasSuffix(pkg.ID, ".test")
+}
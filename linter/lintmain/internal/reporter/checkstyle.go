@@ -0,0 +1,70 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+type checkstyleFile struct {
+	XMLName xml.Name          `xml:"file"`
+	Name    string            `xml:"name,attr"`
+	Errors  []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// CheckstyleReporter renders issues as a checkstyle XML report, the
+// format Jenkins' and GitLab's checkstyle plugins consume. Issues are
+// buffered and grouped by file, then written out as one document on Flush.
+type CheckstyleReporter struct {
+	w     io.Writer
+	files map[string]*checkstyleFile
+	order []string
+}
+
+func NewCheckstyleReporter(w io.Writer) *CheckstyleReporter {
+	return &CheckstyleReporter{w: w, files: make(map[string]*checkstyleFile)}
+}
+
+func (r *CheckstyleReporter) Report(issue Issue) {
+	f := r.files[issue.Filename]
+	if f == nil {
+		f = &checkstyleFile{Name: issue.Filename}
+		r.files[issue.Filename] = f
+		r.order = append(r.order, issue.Filename)
+	}
+	f.Errors = append(f.Errors, checkstyleError{
+		Line:     issue.Line,
+		Column:   issue.Column,
+		Severity: severityOrDefault(issue.Severity),
+		Message:  issue.Message,
+		Source:   "lintpack." + issue.Checker,
+	})
+}
+
+func (r *CheckstyleReporter) Flush() error {
+	doc := struct {
+		XMLName xml.Name          `xml:"checkstyle"`
+		Version string            `xml:"version,attr"`
+		Files   []*checkstyleFile `xml:"file"`
+	}{Version: "5.0"}
+	for _, name := range r.order {
+		doc.Files = append(doc.Files, r.files[name])
+	}
+
+	if _, err := io.WriteString(r.w, xml.Header); err != nil {
+		return err
+	}
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = r.w.Write(append(data, '\n'))
+	return err
+}
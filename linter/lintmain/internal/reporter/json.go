@@ -0,0 +1,47 @@
+package reporter
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// JSONReporter writes one JSON object per issue, one per line, so
+// output can be streamed and processed with tools like jq.
+type JSONReporter struct {
+	w *bufio.Writer
+}
+
+func NewJSONReporter(w io.Writer) *JSONReporter {
+	return &JSONReporter{w: bufio.NewWriter(w)}
+}
+
+type jsonIssue struct {
+	Checker  string `json:"checker"`
+	Filename string `json:"filename"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+}
+
+func (r *JSONReporter) Report(issue Issue) {
+	line, err := json.Marshal(jsonIssue{
+		Checker:  issue.Checker,
+		Filename: issue.Filename,
+		Line:     issue.Line,
+		Column:   issue.Column,
+		Message:  issue.Message,
+		Severity: severityOrDefault(issue.Severity),
+	})
+	if err != nil {
+		// jsonIssue holds only strings and ints, so this can't fail.
+		panic(err)
+	}
+	r.w.Write(line)
+	r.w.WriteByte('\n')
+}
+
+func (r *JSONReporter) Flush() error {
+	return r.w.Flush()
+}
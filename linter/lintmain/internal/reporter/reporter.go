@@ -0,0 +1,52 @@
+// Package reporter renders linter results in the format CI tooling
+// expects, decoupled from how warnings were produced.
+package reporter
+
+import (
+	"fmt"
+	"io"
+)
+
+// Issue is a single reported problem, stripped of any AST/types
+// representation so every Reporter implementation can stay free of
+// go/ast and go/types.
+type Issue struct {
+	Checker  string
+	Filename string
+	Line     int
+	Column   int
+	Message  string
+	// Severity is one of "error", "warning" or "info". It defaults to
+	// "warning" when empty.
+	Severity string
+}
+
+// Reporter consumes Issues and presents them to the user or to
+// whatever consumes the selected -format's output.
+type Reporter interface {
+	Report(issue Issue)
+	Flush() error
+}
+
+// New constructs the Reporter registered under name.
+func New(name string, w io.Writer, colored, shorterErrLocation bool) (Reporter, error) {
+	switch name {
+	case "", "text":
+		return NewTextReporter(w, colored, shorterErrLocation), nil
+	case "json":
+		return NewJSONReporter(w), nil
+	case "checkstyle":
+		return NewCheckstyleReporter(w), nil
+	case "sarif":
+		return NewSARIFReporter(w), nil
+	default:
+		return nil, fmt.Errorf("unknown -format %q", name)
+	}
+}
+
+func severityOrDefault(severity string) string {
+	if severity == "" {
+		return "warning"
+	}
+	return severity
+}
@@ -0,0 +1,124 @@
+package reporter
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// The types below mirror the subset of the SARIF 2.1.0 schema that
+// GitHub's code-scanning upload endpoint requires.
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// SARIFReporter buffers issues and renders them as a single SARIF
+// 2.1.0 log consumable by GitHub code-scanning's upload endpoint.
+type SARIFReporter struct {
+	w       io.Writer
+	seen    map[string]bool
+	ruleIDs []string
+	results []sarifResult
+}
+
+func NewSARIFReporter(w io.Writer) *SARIFReporter {
+	return &SARIFReporter{w: w, seen: make(map[string]bool)}
+}
+
+func (r *SARIFReporter) Report(issue Issue) {
+	if !r.seen[issue.Checker] {
+		r.seen[issue.Checker] = true
+		r.ruleIDs = append(r.ruleIDs, issue.Checker)
+	}
+	r.results = append(r.results, sarifResult{
+		RuleID:  issue.Checker,
+		Level:   sarifLevel(issue.Severity),
+		Message: sarifMessage{Text: issue.Message},
+		Locations: []sarifLocation{{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: issue.Filename},
+				Region:           sarifRegion{StartLine: issue.Line, StartColumn: issue.Column},
+			},
+		}},
+	})
+}
+
+func (r *SARIFReporter) Flush() error {
+	rules := make([]sarifRule, len(r.ruleIDs))
+	for i, id := range r.ruleIDs {
+		rules[i] = sarifRule{ID: id}
+	}
+
+	doc := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "lintpack", Rules: rules}},
+			Results: r.results,
+		}},
+	}
+
+	enc := json.NewEncoder(r.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+func sarifLevel(severity string) string {
+	switch severity {
+	case "error":
+		return "error"
+	case "info":
+		return "note"
+	default:
+		return "warning"
+	}
+}
@@ -0,0 +1,58 @@
+package reporter
+
+import (
+	"bufio"
+	"fmt"
+	"go/build"
+	"io"
+	"strings"
+
+	"github.com/logrusorgru/aurora"
+)
+
+// TextReporter prints issues as human-readable lines, the historical
+// default lintpack output.
+type TextReporter struct {
+	w                  *bufio.Writer
+	Colored            bool
+	ShorterErrLocation bool
+}
+
+// NewTextReporter returns a TextReporter that writes to w, so -format=text
+// shares the same output stream as every other format.
+func NewTextReporter(w io.Writer, colored, shorterErrLocation bool) *TextReporter {
+	return &TextReporter{
+		w:                  bufio.NewWriter(w),
+		Colored:            colored,
+		ShorterErrLocation: shorterErrLocation,
+	}
+}
+
+func (r *TextReporter) Report(issue Issue) {
+	loc := fmt.Sprintf("%s:%d:%d", issue.Filename, issue.Line, issue.Column)
+	if r.ShorterErrLocation {
+		loc = shortenLocation(loc)
+	}
+
+	if r.Colored {
+		fmt.Fprintf(r.w, "%v: %v: %v\n",
+			aurora.Magenta(aurora.Bold(loc)),
+			aurora.Red(issue.Checker),
+			issue.Message)
+	} else {
+		fmt.Fprintf(r.w, "%s: %s: %s\n", loc, issue.Checker, issue.Message)
+	}
+}
+
+func (r *TextReporter) Flush() error { return r.w.Flush() }
+
+func shortenLocation(loc string) string {
+	switch {
+	case strings.HasPrefix(loc, build.Default.GOPATH):
+		return strings.Replace(loc, build.Default.GOPATH, "$GOPATH", 1)
+	case strings.HasPrefix(loc, build.Default.GOROOT):
+		return strings.Replace(loc, build.Default.GOROOT, "$GOROOT", 1)
+	default:
+		return loc
+	}
+}
@@ -3,6 +3,7 @@ package linttest
 import (
 	"go/ast"
 	"go/parser"
+	"go/token"
 	"go/types"
 	"path/filepath"
 	"runtime"
@@ -11,7 +12,7 @@ import (
 	"testing"
 
 	"github.com/go-lintpack/lintpack"
-	"golang.org/x/tools/go/loader"
+	"golang.org/x/tools/go/packages"
 )
 
 var sizes = types.SizesFor("gc", runtime.GOARCH)
@@ -22,13 +23,12 @@ func saneCheckersList(t *testing.T) []*lintpack.CheckerInfo {
 	for _, info := range lintpack.GetCheckersInfo() {
 		pkgPath := "github.com/go-lintpack/lintpack/linttest/testdata/sanity"
 		t.Run("sanity/"+info.Name, func(t *testing.T) {
-			prog := newProg(t, pkgPath)
-			pkgInfo := prog.Imported[pkgPath]
+			pkg, fset := newProg(t, pkgPath)
 			ctx := &lintpack.Context{
 				SizesInfo: sizes,
-				FileSet:   prog.Fset,
-				TypesInfo: &pkgInfo.Info,
-				Pkg:       pkgInfo.Pkg,
+				FileSet:   fset,
+				TypesInfo: pkg.TypesInfo,
+				Pkg:       pkg.Types,
 			}
 			c := lintpack.NewChecker(ctx, info)
 			defer func() {
@@ -39,8 +39,8 @@ func saneCheckersList(t *testing.T) []*lintpack.CheckerInfo {
 					saneList = append(saneList, info)
 				}
 			}()
-			for _, f := range pkgInfo.Files {
-				ctx.SetFileInfo(getFilename(prog, f), f)
+			for _, f := range pkg.Syntax {
+				ctx.SetFileInfo(getFilename(fset, f), f)
 				_ = c.Check(f)
 			}
 		})
@@ -49,35 +49,74 @@ func saneCheckersList(t *testing.T) []*lintpack.CheckerInfo {
 	return saneList
 }
 
+// TestCheckersOption customizes TestCheckers behavior.
+type TestCheckersOption func(*testCheckersConfig)
+
+type testCheckersConfig struct {
+	// paramOverrides maps a checker name to the parameter values
+	// that should replace its defaults for the duration of the test.
+	paramOverrides map[string]map[string]interface{}
+}
+
+// WithParams overrides checkerName's parameter values for the test run,
+// so a checker can be exercised with a non-default configuration
+// (e.g. a lower complexity threshold).
+func WithParams(checkerName string, params map[string]interface{}) TestCheckersOption {
+	return func(cfg *testCheckersConfig) {
+		if cfg.paramOverrides == nil {
+			cfg.paramOverrides = make(map[string]map[string]interface{})
+		}
+		cfg.paramOverrides[checkerName] = params
+	}
+}
+
+func applyParamOverrides(info *lintpack.CheckerInfo, overrides map[string]interface{}) (restore func()) {
+	old := make(map[string]interface{}, len(overrides))
+	for pname, v := range overrides {
+		if param, ok := info.Params[pname]; ok {
+			old[pname] = param.Value
+			param.Value = v
+		}
+	}
+	return func() {
+		for pname, v := range old {
+			info.Params[pname].Value = v
+		}
+	}
+}
+
 // TestCheckers runs end2end tests over all registered checkers using default options.
 //
 // TODO(Quasilyte): document default options.
-// TODO(Quasilyte): make it possible to run tests with different options.
-func TestCheckers(t *testing.T) {
+func TestCheckers(t *testing.T, opts ...TestCheckersOption) {
+	cfg := &testCheckersConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
 	for _, info := range saneCheckersList(t) {
 		t.Run(info.Name, func(t *testing.T) {
+			defer applyParamOverrides(info, cfg.paramOverrides[info.Name])()
+
 			pkgPath := "./testdata/" + info.Name
 
-			prog := newProg(t, pkgPath)
-			pkgInfo := prog.Imported[pkgPath]
+			pkg, fset := newProg(t, pkgPath)
 			ctx := &lintpack.Context{
 				SizesInfo: sizes,
-				FileSet:   prog.Fset,
-				TypesInfo: &pkgInfo.Info,
-				Pkg:       pkgInfo.Pkg,
+				FileSet:   fset,
+				TypesInfo: pkg.TypesInfo,
+				Pkg:       pkg.Types,
 			}
 			c := lintpack.NewChecker(ctx, info)
 
-			checkFiles(t, c, ctx, prog, pkgPath)
+			checkFiles(t, c, ctx, fset, pkg)
 		})
 	}
 }
 
-func checkFiles(t *testing.T, c *lintpack.Checker, ctx *lintpack.Context, prog *loader.Program, pkgPath string) {
-	files := prog.Imported[pkgPath].Files
-
-	for _, f := range files {
-		filename := getFilename(prog, f)
+func checkFiles(t *testing.T, c *lintpack.Checker, ctx *lintpack.Context, fset *token.FileSet, pkg *packages.Package) {
+	for _, f := range pkg.Syntax {
+		filename := getFilename(fset, f)
 		testFilename := filepath.Join("testdata", c.Info.Name, filename)
 		goldenWarns := newGoldenFile(t, testFilename)
 
@@ -103,41 +142,54 @@ func checkFiles(t *testing.T, c *lintpack.Checker, ctx *lintpack.Context, prog *
 	}
 }
 
-// stripDirectives replaces "///" comments with empty single-line
-// comments, so the checkers that inspect comments see ordinary
-// comment groups (with extra newlines, but that's not important).
+// stripDirectives replaces "///" golden-file markers with empty
+// single-line comments, so the checkers that inspect comments see
+// ordinary comment groups (with extra newlines, but that's not
+// important). lintpack:ignore/lintpack:file-ignore directives are left
+// untouched; checkFiles does not apply them, so every warning a checker
+// produces is still compared against the golden markers.
 func stripDirectives(f *ast.File) {
 	for _, cg := range f.Comments {
 		for _, c := range cg.List {
-			if strings.HasPrefix(c.Text, "/// ") {
+			if strings.HasPrefix(c.Text, "/// ") && !isIgnoreDirective(c.Text) {
 				c.Text = "//"
 			}
 		}
 	}
 }
 
-func getFilename(prog *loader.Program, f *ast.File) string {
+func isIgnoreDirective(text string) bool {
+	body := strings.TrimPrefix(text, "/// ")
+	return strings.HasPrefix(body, "lintpack:ignore ") ||
+		strings.HasPrefix(body, "lintpack:file-ignore ")
+}
+
+func getFilename(fset *token.FileSet, f *ast.File) string {
 	// see https://github.com/golang/go/issues/24498
-	return filepath.Base(prog.Fset.Position(f.Pos()).Filename)
+	return filepath.Base(fset.Position(f.Pos()).Filename)
 }
 
-func newProg(t *testing.T, pkgPath string) *loader.Program {
-	conf := loader.Config{
-		ParserMode: parser.ParseComments,
-		TypeChecker: types.Config{
-			Sizes: sizes,
+func newProg(t *testing.T, pkgPath string) (*packages.Package, *token.FileSet) {
+	fset := token.NewFileSet()
+	conf := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo | packages.NeedDeps |
+			packages.NeedImports | packages.NeedTypesSizes,
+		Fset: fset,
+		ParseFile: func(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+			return parser.ParseFile(fset, filename, src, parser.ParseComments)
 		},
 	}
-	if _, err := conf.FromArgs([]string{pkgPath}, true); err != nil {
-		t.Fatalf("resolve packages: %v", err)
-	}
-	prog, err := conf.Load()
+
+	pkgs, err := packages.Load(conf, pkgPath)
 	if err != nil {
-		t.Fatal(err)
+		t.Fatalf("resolve packages: %v", err)
 	}
-	pkgInfo := prog.Imported[pkgPath]
-	if pkgInfo == nil || !pkgInfo.TransitivelyErrorFree {
+	if packages.PrintErrors(pkgs) != 0 {
 		t.Fatalf("%s package is not properly loaded", pkgPath)
 	}
-	return prog
+	if len(pkgs) != 1 {
+		t.Fatalf("%s: expected a single package, got %d", pkgPath, len(pkgs))
+	}
+	return pkgs[0], fset
 }
@@ -0,0 +1,41 @@
+package lintpack
+
+// CheckerParam describes a single configurable checker parameter.
+//
+// The concrete type of Value (int, bool or string) pins the type of
+// the parameter for the lifetime of the program: the CLI flag and
+// config file bindings built around a CheckerParam parse incoming
+// values as whatever type Value already holds.
+type CheckerParam struct {
+	// Value holds the parameter's current value. It is set to the
+	// checker's default by the checker constructor and may be
+	// overwritten by a CLI flag or a config file entry afterwards.
+	Value interface{}
+
+	// Usage is a one-line description shown in the -help output.
+	Usage string
+}
+
+// CheckerParams is a resolved set of a single checker's parameters,
+// indexed by parameter name.
+//
+// *Context carries the CheckerParams of whatever checker is currently
+// being constructed, so a checker's constructor can read its own
+// configuration through ctx.Params without knowing how the values
+// were ultimately provided (CLI flag, config file or default).
+type CheckerParams map[string]*CheckerParam
+
+// Int returns the current value of an int-typed parameter named name.
+func (params CheckerParams) Int(name string) int {
+	return params[name].Value.(int)
+}
+
+// Bool returns the current value of a bool-typed parameter named name.
+func (params CheckerParams) Bool(name string) bool {
+	return params[name].Value.(bool)
+}
+
+// String returns the current value of a string-typed parameter named name.
+func (params CheckerParams) String(name string) string {
+	return params[name].Value.(string)
+}
@@ -0,0 +1,20 @@
+package lintpack
+
+import "go/ast"
+
+// Warning is a single diagnostic produced by a Checker. Node anchors it
+// to a position in the source file being checked.
+type Warning struct {
+	Node ast.Node
+	Text string
+
+	// Severity is one of "error", "warning" or "info", letting a
+	// checker distinguish how serious a finding is in structured
+	// output (-format=json/checkstyle/sarif). It's optional: an empty
+	// Severity means "warning", the historical default.
+	//
+	// The original request also proposed a Kind field, but nothing
+	// in this series consumes it, so it's left out rather than added
+	// speculatively; add it alongside its first consumer instead.
+	Severity string
+}